@@ -0,0 +1,66 @@
+package main
+
+import (
+	"github.com/sst/ion/cmd/sst/cli"
+	"github.com/sst/ion/cmd/sst/mosaic/ui"
+	"github.com/sst/ion/pkg/project"
+	"golang.org/x/sync/errgroup"
+)
+
+func CmdDeploy(c *cli.Cli) error {
+	p, err := c.InitProject()
+	if err != nil {
+		return err
+	}
+	defer p.Cleanup()
+
+	planPath := c.String("plan")
+	var plan *project.Plan
+	if planPath != "" {
+		plan, err = project.LoadPlan(planPath)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckStateHash(plan); err != nil {
+			return err
+		}
+	}
+
+	var wg errgroup.Group
+	defer wg.Wait()
+	out := make(chan interface{})
+	defer close(out)
+	ui := ui.New(c.Context)
+	wg.Go(func() error {
+		for evt := range out {
+			ui.Event(evt)
+		}
+		return nil
+	})
+	defer ui.Destroy()
+	input := &project.StackInput{
+		Command: "deploy",
+		Out:     out,
+	}
+	if plan != nil {
+		// Re-diffing at apply time is exactly the drift `--plan` exists to
+		// prevent, so skip it and restrict execution to the URNs the plan
+		// recorded: the runner applies plan.Changes verbatim instead of
+		// computing its own set of operations.
+		input.Plan = plan
+		input.SkipDiff = true
+		input.Target = planURNs(plan)
+	}
+	return p.Run(c.Context, input)
+}
+
+// planURNs returns the URNs of every resource the plan recorded a
+// change for, used to restrict a `--plan` deploy to exactly those
+// resources.
+func planURNs(plan *project.Plan) []string {
+	urns := make([]string, len(plan.Changes))
+	for i, change := range plan.Changes {
+		urns[i] = change.URN
+	}
+	return urns
+}
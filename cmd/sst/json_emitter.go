@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/sst/ion/pkg/project/policy"
+)
+
+// jsonEvent is the stable schema written one-per-line to stdout when
+// `sst preview --format json|ndjson` is used. CI pipelines can rely on
+// this shape across releases instead of scraping TUI output.
+type jsonEvent struct {
+	Type      string      `json:"type"`
+	URN       string      `json:"urn,omitempty"`
+	Op        string      `json:"op,omitempty"`
+	Diff      interface{} `json:"diff,omitempty"`
+	Provider  string      `json:"provider,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// jsonEmitter writes preview events as newline-delimited JSON, one
+// object per event, for scripted consumption (GitHub Actions, Jenkins).
+type jsonEmitter struct {
+	enc *json.Encoder
+}
+
+func newJSONEmitter(w io.Writer) *jsonEmitter {
+	return &jsonEmitter{enc: json.NewEncoder(w)}
+}
+
+func (j *jsonEmitter) Event(evt interface{}) {
+	je := jsonEvent{
+		Type:      "event",
+		Timestamp: time.Now().UTC(),
+	}
+	switch v := evt.(type) {
+	case error:
+		je.Type = "error"
+		je.Error = v.Error()
+	case policy.Violation:
+		je.Type = "policy." + v.Level
+		je.Error = v.Message
+	default:
+		if re, ok := toResourceChange(evt); ok {
+			je.Type = "resource"
+			je.URN = re.URN
+			je.Op = re.Op
+			je.Provider = re.Provider
+			je.Diff = re.Diff
+		}
+	}
+	j.enc.Encode(je)
+}
+
+func (j *jsonEmitter) Destroy() {}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sst/ion/pkg/project/policy"
+)
+
+// fakeResourceEvent satisfies the resourceEvent interface toResourceChange
+// expects, so tests can drive jsonEmitter without a real preview event.
+type fakeResourceEvent struct {
+	urn, op, provider string
+}
+
+func (f fakeResourceEvent) URN() string                    { return f.urn }
+func (f fakeResourceEvent) Op() string                     { return f.op }
+func (f fakeResourceEvent) Provider() string               { return f.provider }
+func (f fakeResourceEvent) Inputs() map[string]interface{} { return nil }
+func (f fakeResourceEvent) Diff() map[string]interface{}   { return map[string]interface{}{"a": 1} }
+func (f fakeResourceEvent) Tags() map[string]string        { return nil }
+
+func decodeLastLine(t *testing.T, buf *bytes.Buffer) jsonEvent {
+	t.Helper()
+	var je jsonEvent
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if err := json.Unmarshal(lines[len(lines)-1], &je); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return je
+}
+
+func TestJSONEmitterResourceEvent(t *testing.T) {
+	var buf bytes.Buffer
+	e := newJSONEmitter(&buf)
+	e.Event(fakeResourceEvent{urn: "urn:sst:a", op: "create", provider: "aws"})
+
+	je := decodeLastLine(t, &buf)
+	if je.Type != "resource" || je.URN != "urn:sst:a" || je.Op != "create" || je.Provider != "aws" {
+		t.Errorf("unexpected event: %+v", je)
+	}
+}
+
+func TestJSONEmitterNoopResourceEvent(t *testing.T) {
+	var buf bytes.Buffer
+	e := newJSONEmitter(&buf)
+	e.Event(fakeResourceEvent{urn: "urn:sst:a", op: noopOp, provider: "aws"})
+
+	je := decodeLastLine(t, &buf)
+	if je.Type != "resource" || je.URN != "urn:sst:a" || je.Op != noopOp {
+		t.Errorf("expected a noop resource to still be emitted as a resource event, got %+v", je)
+	}
+}
+
+func TestJSONEmitterError(t *testing.T) {
+	var buf bytes.Buffer
+	e := newJSONEmitter(&buf)
+	e.Event(errors.New("boom"))
+
+	je := decodeLastLine(t, &buf)
+	if je.Type != "error" || je.Error != "boom" {
+		t.Errorf("unexpected event: %+v", je)
+	}
+}
+
+func TestJSONEmitterPolicyViolation(t *testing.T) {
+	var buf bytes.Buffer
+	e := newJSONEmitter(&buf)
+	e.Event(policy.Violation{Policy: "no-public-buckets", Level: "deny", Message: "bucket is public"})
+
+	je := decodeLastLine(t, &buf)
+	if je.Type != "policy.deny" || je.Error != "bucket is public" {
+		t.Errorf("unexpected event: %+v", je)
+	}
+}
+
+func TestJSONEmitterUnrecognizedEvent(t *testing.T) {
+	var buf bytes.Buffer
+	e := newJSONEmitter(&buf)
+	e.Event("some log line")
+
+	je := decodeLastLine(t, &buf)
+	if je.Type != "event" {
+		t.Errorf("unexpected event: %+v", je)
+	}
+}
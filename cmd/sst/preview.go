@@ -1,12 +1,24 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/sst/ion/cmd/sst/cli"
 	"github.com/sst/ion/cmd/sst/mosaic/ui"
 	"github.com/sst/ion/pkg/project"
+	"github.com/sst/ion/pkg/project/policy"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/term"
 )
 
+// emitter receives preview events as they stream in, either to render
+// them in the TUI or to forward them to scripted consumers.
+type emitter interface {
+	Event(evt interface{})
+	Destroy()
+}
+
 func CmdPreview(c *cli.Cli) error {
 	p, err := c.InitProject()
 	if err != nil {
@@ -14,24 +26,143 @@ func CmdPreview(c *cli.Cli) error {
 	}
 	defer p.Cleanup()
 
+	outPath := c.String("out")
+	policies := p.Config().Policies
+	detailedExitCode := c.Bool("detailed-exitcode")
+	targets := c.StringSlice("target")
+	excludes := c.StringSlice("exclude")
+
+	// Only accumulate the plan (and, for --out, hash state) when a flag
+	// actually needs it - most `sst preview` runs use none of these.
+	needsPlan := outPath != "" || len(policies) > 0 || detailedExitCode
+	var plan *project.Plan
+	if needsPlan {
+		plan = &project.Plan{}
+	}
+
+	format := c.String("format")
+	if format == "" {
+		format = os.Getenv("SST_OUTPUT")
+	}
+	if format == "" && !term.IsTerminal(int(os.Stdout.Fd())) {
+		format = "json"
+	}
+
+	var emit emitter
+	if format == "json" || format == "ndjson" {
+		emit = newJSONEmitter(os.Stdout)
+	} else {
+		emit = ui.New(c.Context)
+	}
+
 	var wg errgroup.Group
-	defer wg.Wait()
 	out := make(chan interface{})
-	defer close(out)
-	ui := ui.New(c.Context)
 	wg.Go(func() error {
 		for evt := range out {
-			ui.Event(evt)
+			emit.Event(evt)
+			if plan != nil {
+				if change, ok := toPlannedChange(evt); ok {
+					plan.Changes = append(plan.Changes, change)
+				}
+			}
 		}
 		return nil
 	})
-	defer ui.Destroy()
+	defer emit.Destroy()
 	err = p.Run(c.Context, &project.StackInput{
 		Command: "preview",
 		Out:     out,
+		Target:  targets,
+		Exclude: excludes,
 	})
+	close(out)
+	wg.Wait()
 	if err != nil {
 		return err
 	}
+
+	if outPath != "" {
+		hash, err := p.StateHash()
+		if err != nil {
+			return err
+		}
+		plan.StateHash = hash
+		if err := project.SavePlan(outPath, plan); err != nil {
+			return err
+		}
+	}
+
+	if len(policies) > 0 {
+		policyInput := policy.PlanInput{Changes: make([]policy.Change, len(plan.Changes))}
+		for i, change := range plan.Changes {
+			policyInput.Changes[i] = policy.Change{
+				URN:      change.URN,
+				Op:       change.Op,
+				Provider: change.Provider,
+				Inputs:   change.Inputs,
+				Diff:     change.Diff,
+				Tags:     change.Tags,
+			}
+		}
+		violations, err := policy.Evaluate(c.Context, policies, policyInput)
+		if err != nil {
+			return err
+		}
+		for _, v := range violations {
+			emit.Event(v)
+		}
+		policyMode := c.String("policy")
+		if policyMode != "warn" && policy.HasDeny(violations) {
+			return fmt.Errorf("policy violations found, refusing to continue (pass --policy=warn to downgrade to warnings)")
+		}
+	}
+
+	if detailedExitCode && len(plan.Changes) > 0 {
+		return cli.NewExitError(2, fmt.Errorf("changes pending"))
+	}
 	return nil
 }
+
+// noopOp is the Op value resourceEvents report for a resource that is
+// already up to date.
+const noopOp = "same"
+
+// toResourceChange narrows a raw preview event down to the subset of
+// fields the JSON emitter and plan accumulation both care about. Events
+// that aren't resource changes (logs, diagnostics, summaries) are
+// ignored; noop resources ("same") are kept so consumers of the ndjson
+// stream can still see that a resource was evaluated.
+func toResourceChange(evt interface{}) (project.ResourceChange, bool) {
+	type resourceEvent interface {
+		URN() string
+		Op() string
+		Provider() string
+		Inputs() map[string]interface{}
+		Diff() map[string]interface{}
+		Tags() map[string]string
+	}
+	re, ok := evt.(resourceEvent)
+	if !ok {
+		return project.ResourceChange{}, false
+	}
+	return project.ResourceChange{
+		URN:      re.URN(),
+		Op:       re.Op(),
+		Provider: re.Provider(),
+		Inputs:   re.Inputs(),
+		Diff:     re.Diff(),
+		Tags:     re.Tags(),
+	}, true
+}
+
+// toPlannedChange is toResourceChange filtered down to actual changes.
+// It backs plan accumulation (--out, policy evaluation, and
+// --detailed-exitcode), none of which should count or persist a
+// resource that's already up to date.
+func toPlannedChange(evt interface{}) (project.ResourceChange, bool) {
+	change, ok := toResourceChange(evt)
+	if !ok || change.Op == noopOp {
+		return project.ResourceChange{}, false
+	}
+	return change, true
+}
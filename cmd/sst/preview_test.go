@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestToResourceChangeKeepsNoops(t *testing.T) {
+	change, ok := toResourceChange(fakeResourceEvent{urn: "urn:sst:a", op: noopOp})
+	if !ok || change.Op != noopOp {
+		t.Errorf("expected a no-op resource event to pass through as op %q, got %+v, ok=%v", noopOp, change, ok)
+	}
+}
+
+func TestToResourceChangeIgnoresUnrelatedEvents(t *testing.T) {
+	if _, ok := toResourceChange("a log line"); ok {
+		t.Error("expected a non-resource event to be excluded")
+	}
+}
+
+func TestToPlannedChangeExcludesNoops(t *testing.T) {
+	_, ok := toPlannedChange(fakeResourceEvent{urn: "urn:sst:a", op: noopOp})
+	if ok {
+		t.Error("expected a no-op resource event to be excluded from the plan")
+	}
+}
+
+func TestToPlannedChangeIncludesRealChanges(t *testing.T) {
+	for _, op := range []string{"create", "update", "delete", "replace"} {
+		change, ok := toPlannedChange(fakeResourceEvent{urn: "urn:sst:a", op: op})
+		if !ok {
+			t.Errorf("op %q: expected it to be included in the plan", op)
+			continue
+		}
+		if change.Op != op {
+			t.Errorf("op %q: got change.Op = %q", op, change.Op)
+		}
+	}
+}
+
+func TestToPlannedChangeIgnoresUnrelatedEvents(t *testing.T) {
+	if _, ok := toPlannedChange("a log line"); ok {
+		t.Error("expected a non-resource event to be excluded")
+	}
+}
@@ -0,0 +1,67 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ResourceChange describes a single planned change to a resource as
+// captured during `sst preview --out`.
+type ResourceChange struct {
+	URN      string                 `json:"urn"`
+	Op       string                 `json:"op"`
+	Provider string                 `json:"provider,omitempty"`
+	Inputs   map[string]interface{} `json:"inputs,omitempty"`
+	Diff     map[string]interface{} `json:"diff,omitempty"`
+	Tags     map[string]string      `json:"tags,omitempty"`
+}
+
+// Plan is the serialized output of a preview run. It is written by
+// `sst preview --out` and later consumed by `sst deploy --plan` so that
+// apply executes exactly what was previewed.
+type Plan struct {
+	StateHash string           `json:"stateHash"`
+	Changes   []ResourceChange `json:"changes"`
+}
+
+// SavePlan writes the plan to path as JSON.
+func SavePlan(path string, plan *Plan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadPlan reads a plan previously written by SavePlan.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	if err := json.Unmarshal(data, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// CheckStateHash returns an error if the plan was captured against a
+// different state than the one currently on disk, so `sst deploy --plan`
+// refuses to apply a stale plan.
+func (p *Project) CheckStateHash(plan *Plan) error {
+	current, err := p.StateHash()
+	if err != nil {
+		return err
+	}
+	return compareStateHash(current, plan.StateHash)
+}
+
+// compareStateHash is the pure comparison CheckStateHash delegates to.
+func compareStateHash(current, expected string) error {
+	if current != expected {
+		return fmt.Errorf("plan is stale: state has changed since this plan was created, run `sst preview --out` again")
+	}
+	return nil
+}
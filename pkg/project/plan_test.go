@@ -0,0 +1,70 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadPlanRoundTrip(t *testing.T) {
+	plan := &Plan{
+		StateHash: "abc123",
+		Changes: []ResourceChange{
+			{URN: "urn:sst:a", Op: "create", Provider: "aws", Tags: map[string]string{"cost-center": "eng"}},
+			{URN: "urn:sst:b", Op: "update", Diff: map[string]interface{}{"field": "old"}},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := SavePlan(path, plan); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+
+	loaded, err := LoadPlan(path)
+	if err != nil {
+		t.Fatalf("LoadPlan: %v", err)
+	}
+	if loaded.StateHash != plan.StateHash {
+		t.Errorf("StateHash = %q, want %q", loaded.StateHash, plan.StateHash)
+	}
+	if len(loaded.Changes) != len(plan.Changes) {
+		t.Fatalf("len(Changes) = %d, want %d", len(loaded.Changes), len(plan.Changes))
+	}
+	for i, c := range plan.Changes {
+		if loaded.Changes[i].URN != c.URN || loaded.Changes[i].Op != c.Op {
+			t.Errorf("Changes[%d] = %+v, want %+v", i, loaded.Changes[i], c)
+		}
+	}
+	if loaded.Changes[0].Tags["cost-center"] != "eng" {
+		t.Errorf("Changes[0].Tags = %+v, want cost-center=eng", loaded.Changes[0].Tags)
+	}
+}
+
+func TestLoadPlanMissingFile(t *testing.T) {
+	if _, err := LoadPlan(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected error loading a missing plan file")
+	}
+}
+
+func TestCompareStateHash(t *testing.T) {
+	cases := []struct {
+		name    string
+		current string
+		planned string
+		wantErr bool
+	}{
+		{"matching hashes", "abc123", "abc123", false},
+		{"stale plan", "abc123", "def456", true},
+		{"empty vs non-empty", "", "abc123", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := compareStateHash(tc.current, tc.planned)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
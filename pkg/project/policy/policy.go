@@ -0,0 +1,133 @@
+// Package policy evaluates Rego policies against a preview plan, so
+// teams can enforce rules like "no public S3 buckets" or "no deletions
+// in prod" as part of `sst preview` instead of as a separate CI step.
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// Mode controls how CmdPreview reacts to violations.
+type Mode string
+
+const (
+	ModeDeny Mode = "deny"
+	ModeWarn Mode = "warn"
+)
+
+// Policy is a single named Rego source, either a path to a .rego file
+// or an inline rule body, as declared under `policy` in sst.config.ts.
+type Policy struct {
+	Name string
+	Path string
+	Rule string
+}
+
+// Violation is one `deny` or `warn` result produced by a policy.
+type Violation struct {
+	Policy  string `json:"policy"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// Change is the subset of a planned resource change that policies are
+// evaluated against. The caller builds this from project.Plan so this
+// package has no dependency back on pkg/project.
+type Change struct {
+	URN      string                 `json:"urn"`
+	Op       string                 `json:"op"`
+	Provider string                 `json:"provider,omitempty"`
+	Inputs   map[string]interface{} `json:"inputs,omitempty"`
+	Diff     map[string]interface{} `json:"diff,omitempty"`
+	Tags     map[string]string      `json:"tags,omitempty"`
+}
+
+// PlanInput is the Rego evaluation input: the set of planned changes a
+// preview produced.
+type PlanInput struct {
+	Changes []Change
+}
+
+// Evaluate runs every policy against the plan and returns all violations,
+// tagged with the level (deny/warn) the rule was declared under.
+func Evaluate(ctx context.Context, policies []Policy, plan PlanInput) ([]Violation, error) {
+	input := map[string]interface{}{
+		"changes": plan.Changes,
+	}
+
+	var violations []Violation
+	for _, p := range policies {
+		byLevel, err := evalPolicy(ctx, p, input)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: %w", p.Name, err)
+		}
+		for _, level := range []string{"deny", "warn"} {
+			for _, msg := range byLevel[level] {
+				violations = append(violations, Violation{
+					Policy:  p.Name,
+					Level:   level,
+					Message: msg,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// evalPolicy compiles the policy once and pulls both its deny and warn
+// rule sets out of a single `data.sst` query, instead of compiling and
+// evaluating the module once per rule.
+func evalPolicy(ctx context.Context, p Policy, input map[string]interface{}) (map[string][]string, error) {
+	opts := []func(*rego.Rego){
+		rego.Query("data.sst"),
+	}
+	if p.Path != "" {
+		opts = append(opts, rego.Load([]string{p.Path}, nil))
+	} else {
+		opts = append(opts, rego.Module(p.Name+".rego", p.Rule))
+	}
+
+	query, err := rego.New(opts...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+
+	byLevel := map[string][]string{}
+	for _, result := range results {
+		for _, expr := range result.Expressions {
+			doc, ok := expr.Value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, level := range []string{"deny", "warn"} {
+				values, ok := doc[level].([]interface{})
+				if !ok {
+					continue
+				}
+				for _, v := range values {
+					if s, ok := v.(string); ok {
+						byLevel[level] = append(byLevel[level], s)
+					}
+				}
+			}
+		}
+	}
+	return byLevel, nil
+}
+
+// HasDeny reports whether any violation was raised under the deny rule.
+func HasDeny(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Level == string(ModeDeny) {
+			return true
+		}
+	}
+	return false
+}
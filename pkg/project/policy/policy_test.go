@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"context"
+	"testing"
+)
+
+const denyOnDeleteRego = `
+package sst
+
+deny[msg] {
+	input.changes[_].op == "delete"
+	msg := "deletions are not allowed"
+}
+
+warn[msg] {
+	input.changes[_].provider == "aws"
+	msg := "review aws changes"
+}
+`
+
+func TestEvaluateDenyAndWarn(t *testing.T) {
+	policies := []Policy{{Name: "no-deletes", Rule: denyOnDeleteRego}}
+	plan := PlanInput{Changes: []Change{
+		{URN: "urn:sst:a", Op: "delete", Provider: "aws"},
+	}}
+
+	violations, err := Evaluate(context.Background(), policies, plan)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if !HasDeny(violations) {
+		t.Errorf("expected a deny violation, got %+v", violations)
+	}
+
+	var sawWarn bool
+	for _, v := range violations {
+		if v.Level == "warn" {
+			sawWarn = true
+		}
+	}
+	if !sawWarn {
+		t.Errorf("expected a warn violation, got %+v", violations)
+	}
+}
+
+const requireCostCenterTagRego = `
+package sst
+
+deny[msg] {
+	not input.changes[_].tags["cost-center"]
+	msg := "resource is missing a cost-center tag"
+}
+`
+
+func TestEvaluateReadsTags(t *testing.T) {
+	policies := []Policy{{Name: "cost-center-tag", Rule: requireCostCenterTagRego}}
+
+	untagged := PlanInput{Changes: []Change{{URN: "urn:sst:a", Op: "create"}}}
+	violations, err := Evaluate(context.Background(), policies, untagged)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if !HasDeny(violations) {
+		t.Errorf("expected an untagged resource to be denied, got %+v", violations)
+	}
+
+	tagged := PlanInput{Changes: []Change{
+		{URN: "urn:sst:a", Op: "create", Tags: map[string]string{"cost-center": "eng"}},
+	}}
+	violations, err = Evaluate(context.Background(), policies, tagged)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if HasDeny(violations) {
+		t.Errorf("expected a tagged resource to pass, got %+v", violations)
+	}
+}
+
+func TestEvaluateNoViolations(t *testing.T) {
+	policies := []Policy{{Name: "no-deletes", Rule: denyOnDeleteRego}}
+	plan := PlanInput{Changes: []Change{
+		{URN: "urn:sst:a", Op: "create", Provider: "gcp"},
+	}}
+
+	violations, err := Evaluate(context.Background(), policies, plan)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %+v", violations)
+	}
+	if HasDeny(violations) {
+		t.Error("HasDeny should be false when there are no violations")
+	}
+}
+
+func TestHasDeny(t *testing.T) {
+	cases := []struct {
+		name       string
+		violations []Violation
+		want       bool
+	}{
+		{"empty", nil, false},
+		{"only warn", []Violation{{Level: "warn"}}, false},
+		{"has deny", []Violation{{Level: "warn"}, {Level: "deny"}}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HasDeny(tc.violations); got != tc.want {
+				t.Errorf("HasDeny() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}